@@ -0,0 +1,169 @@
+// Package audit provides a fixed-size ring buffer of capture/sync decisions plus a manual
+// force-open/force-closed override, shared by the timeselectcamera and timesyncsensor
+// components.
+package audit
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultSize is the number of entries retained when a Log is constructed with size <= 0
+const DefaultSize = 1000
+
+// Entry records a single capture/sync decision. MimeType is only meaningful for camera
+// captures and is left empty by components (such as sensors) with no mime type to report.
+type Entry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Decision     string    `json:"decision"`
+	Reason       string    `json:"reason"`
+	ActiveWindow string    `json:"active_window,omitempty"`
+	MimeType     string    `json:"mime_type,omitempty"`
+}
+
+// Log is a fixed-size ring buffer of Entry plus running counters
+type Log struct {
+	mu         sync.Mutex
+	entries    []Entry
+	next       int
+	full       bool
+	allowed    int
+	skipped    int
+	windowHits map[string]int
+}
+
+// NewLog allocates a Log of the given size, defaulting to DefaultSize
+func NewLog(size int) *Log {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Log{
+		entries:    make([]Entry, size),
+		windowHits: map[string]int{},
+	}
+}
+
+// Size returns the ring buffer's current capacity
+func (l *Log) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
+
+// Resize changes the ring buffer's capacity in place, discarding any buffered entries and
+// resetting the counters, so callers never need to swap the *Log pointer itself (and race
+// concurrent readers that hold the old one).
+func (l *Log) Resize(size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if size <= 0 {
+		size = DefaultSize
+	}
+	l.entries = make([]Entry, size)
+	l.next = 0
+	l.full = false
+	l.allowed = 0
+	l.skipped = 0
+	l.windowHits = map[string]int{}
+}
+
+// Record appends an entry, overwriting the oldest once the buffer is full
+func (l *Log) Record(e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = e
+	l.next++
+	if l.next == len(l.entries) {
+		l.next = 0
+		l.full = true
+	}
+
+	if e.Decision == "allowed" {
+		l.allowed++
+	} else {
+		l.skipped++
+	}
+	if e.ActiveWindow != "" {
+		l.windowHits[e.ActiveWindow]++
+	}
+}
+
+// Snapshot returns the buffered entries in chronological order
+func (l *Log) Snapshot() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.full {
+		out := make([]Entry, l.next)
+		copy(out, l.entries[:l.next])
+		return out
+	}
+
+	out := make([]Entry, len(l.entries))
+	copy(out, l.entries[l.next:])
+	copy(out[len(l.entries)-l.next:], l.entries[:l.next])
+	return out
+}
+
+// Clear empties the buffer and resets the counters
+func (l *Log) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = make([]Entry, len(l.entries))
+	l.next = 0
+	l.full = false
+	l.allowed = 0
+	l.skipped = 0
+	l.windowHits = map[string]int{}
+}
+
+// Stats summarizes the counters recorded since construction or the last Clear or Resize
+func (l *Log) Stats() map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	windowHits := make(map[string]interface{}, len(l.windowHits))
+	for window, count := range l.windowHits {
+		windowHits[window] = count
+	}
+
+	return map[string]interface{}{
+		"allowed":     l.allowed,
+		"skipped":     l.skipped,
+		"window_hits": windowHits,
+	}
+}
+
+// Override temporarily forces the window decision open or closed for a caller-supplied duration
+type Override struct {
+	mu    sync.Mutex
+	mode  string // "open", "closed", or "" for no override
+	until time.Time
+}
+
+// Set activates the override for the given duration
+func (o *Override) Set(mode string, d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.mode = mode
+	o.until = time.Now().Add(d)
+}
+
+// Clear deactivates any active override
+func (o *Override) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.mode = ""
+}
+
+// Active reports the current override mode, if one is set and has not expired
+func (o *Override) Active() (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.mode == "" || time.Now().After(o.until) {
+		return "", false
+	}
+	return o.mode, true
+}
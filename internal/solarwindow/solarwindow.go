@@ -0,0 +1,167 @@
+// Package solarwindow computes sunrise/sunset based windows, shared by the timeselectcamera and
+// timesyncsensor components so the solar position math exists in exactly one place.
+package solarwindow
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Config configures a sunrise/sunset based window for a given location
+type Config struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// Start and End select which solar event opens/closes the window: "sunrise" or "sunset"
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// StartOffset/EndOffset are Go duration strings (e.g. "-30m", "+15m") applied to Start/End
+	StartOffset string `json:"start_offset,omitempty"`
+	EndOffset   string `json:"end_offset,omitempty"`
+	// PolarFallback overrides the default handling of days the sun never rises or never sets.
+	// By default, such a day is in-window exactly when the sun's state matches Start (e.g. for
+	// a Start:"sunrise" window, a day that never sets is in-window and a day that never rises is
+	// not). "always" forces the window open on these days regardless of Start; "never" forces it
+	// closed.
+	PolarFallback string `json:"polar_fallback,omitempty"`
+}
+
+// Validate checks the config for a usable location and well-formed offsets. path identifies the
+// owning resource in error messages.
+func (c *Config) Validate(path string) error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("%s: solar.latitude must be between -90 and 90", path)
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("%s: solar.longitude must be between -180 and 180", path)
+	}
+	if c.Start != "sunrise" && c.Start != "sunset" {
+		return fmt.Errorf("%s: solar.start must be \"sunrise\" or \"sunset\", got %q", path, c.Start)
+	}
+	if c.End != "sunrise" && c.End != "sunset" {
+		return fmt.Errorf("%s: solar.end must be \"sunrise\" or \"sunset\", got %q", path, c.End)
+	}
+	if c.StartOffset != "" {
+		if _, err := time.ParseDuration(c.StartOffset); err != nil {
+			return fmt.Errorf("%s: solar.start_offset invalid %q: %w", path, c.StartOffset, err)
+		}
+	}
+	if c.EndOffset != "" {
+		if _, err := time.ParseDuration(c.EndOffset); err != nil {
+			return fmt.Errorf("%s: solar.end_offset invalid %q: %w", path, c.EndOffset, err)
+		}
+	}
+	switch c.PolarFallback {
+	case "", "always", "never":
+	default:
+		return fmt.Errorf("%s: solar.polar_fallback must be \"always\" or \"never\", got %q", path, c.PolarFallback)
+	}
+	return nil
+}
+
+// Window returns today's window in UTC for t's calendar date. If the sun never rises or never
+// sets on that date (polar night/polar day at this latitude), isPolar is true and polarOpen
+// reports whether the window should be treated as open for the whole day; start/end are unset
+// in that case.
+func (c *Config) Window(t time.Time) (start, end time.Time, polarOpen, isPolar bool) {
+	sunrise, sunset, alwaysUp, alwaysDown := sunriseSunset(c.Latitude, c.Longitude, t)
+	if alwaysUp || alwaysDown {
+		// A window is "daylight-oriented" when it opens at sunrise, in which case a day that
+		// never sets (alwaysUp) is naturally in-window and a day that never rises (alwaysDown)
+		// is naturally out-of-window; a window that opens at sunset is the reverse.
+		daylightWindow := c.Start == "sunrise"
+		open := alwaysUp == daylightWindow
+		switch c.PolarFallback {
+		case "always":
+			open = true
+		case "never":
+			open = false
+		}
+		return time.Time{}, time.Time{}, open, true
+	}
+
+	start = solarEvent(c.Start, sunrise, sunset)
+	if c.StartOffset != "" {
+		if off, err := time.ParseDuration(c.StartOffset); err == nil {
+			start = start.Add(off)
+		}
+	}
+
+	end = solarEvent(c.End, sunrise, sunset)
+	if c.EndOffset != "" {
+		if off, err := time.ParseDuration(c.EndOffset); err == nil {
+			end = end.Add(off)
+		}
+	}
+
+	if end.Before(start) {
+		end = end.Add(24 * time.Hour)
+	}
+	return start, end, false, false
+}
+
+// solarEvent picks sunrise or sunset by name
+func solarEvent(name string, sunrise, sunset time.Time) time.Time {
+	if name == "sunset" {
+		return sunset
+	}
+	return sunrise
+}
+
+// solarZenith is the sun's altitude (degrees below the horizon) used for sunrise/sunset,
+// standard for atmospheric refraction and the sun's apparent radius.
+const solarZenith = -0.8333
+
+// sunriseSunset computes sunrise and sunset in UTC for the given latitude/longitude on t's
+// UTC calendar date, using the NOAA solar position algorithm (the "sunrise equation"):
+// Julian day -> solar mean anomaly -> ecliptic longitude -> declination -> hour angle.
+func sunriseSunset(lat, lon float64, t time.Time) (sunrise, sunset time.Time, alwaysUp, alwaysDown bool) {
+	year, month, day := t.UTC().Date()
+	jDate := julianDayNumber(year, int(month), day)
+
+	n := math.Ceil(jDate - 2451545.0 + 0.0008)
+	jStar := 2451545.0 + n - lon/360
+
+	meanAnomaly := math.Mod(357.5291+0.98560028*(jStar-2451545.0), 360)
+	mRad := deg2rad(meanAnomaly)
+	center := 1.9148*math.Sin(mRad) + 0.02*math.Sin(2*mRad) + 0.0003*math.Sin(3*mRad)
+	eclipticLon := math.Mod(meanAnomaly+102.9372+center+180, 360)
+	eclipticLonRad := deg2rad(eclipticLon)
+
+	jTransit := jStar + 0.0053*math.Sin(mRad) - 0.0069*math.Sin(2*eclipticLonRad)
+
+	sinDeclination := math.Sin(eclipticLonRad) * math.Sin(deg2rad(23.4397))
+	declination := math.Asin(sinDeclination)
+
+	latRad := deg2rad(lat)
+	cosHourAngle := (math.Sin(deg2rad(solarZenith)) - math.Sin(latRad)*sinDeclination) / (math.Cos(latRad) * math.Cos(declination))
+
+	if cosHourAngle > 1 {
+		// Sun never reaches solarZenith above the horizon: polar night
+		return time.Time{}, time.Time{}, false, true
+	}
+	if cosHourAngle < -1 {
+		// Sun never drops to solarZenith: polar day
+		return time.Time{}, time.Time{}, true, false
+	}
+
+	hourAngle := rad2deg(math.Acos(cosHourAngle))
+	sunrise = julianToTime(jTransit - hourAngle/360)
+	sunset = julianToTime(jTransit + hourAngle/360)
+	return sunrise, sunset, false, false
+}
+
+// julianDayNumber approximates the Julian date for 0h UTC on the given Gregorian calendar date
+func julianDayNumber(year, month, day int) float64 {
+	y, m, d := float64(year), float64(month), float64(day)
+	return 367*y - math.Floor(7*(y+math.Floor((m+9)/12))/4) + math.Floor(275*m/9) + d + 1721013.5
+}
+
+// julianToTime converts a Julian date to UTC, relying on JD 2440587.5 == the Unix epoch
+func julianToTime(jd float64) time.Time {
+	unixSeconds := (jd - 2440587.5) * 86400
+	return time.Unix(0, int64(math.Round(unixSeconds*float64(time.Second)))).UTC()
+}
+
+func deg2rad(deg float64) float64 { return deg * math.Pi / 180 }
+func rad2deg(rad float64) float64 { return rad * 180 / math.Pi }
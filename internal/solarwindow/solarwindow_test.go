@@ -0,0 +1,113 @@
+package solarwindow
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSunriseSunset pins sunriseSunset against known reference dates/locations, so a regression
+// in the solar position math (e.g. using the full Julian date instead of days since J2000 for
+// the mean anomaly) shows up as a failing test instead of silently shifting every window.
+func TestSunriseSunset(t *testing.T) {
+	const tolerance = 2 * time.Second
+
+	cases := []struct {
+		name           string
+		lat, lon       float64
+		year, mon, day int
+		wantSunrise    time.Time
+		wantSunset     time.Time
+		wantAlwaysUp   bool
+		wantAlwaysDown bool
+	}{
+		{
+			name: "san francisco summer solstice",
+			lat:  37.7749, lon: -122.4194,
+			year: 2025, mon: 6, day: 21,
+			wantSunrise: time.Date(2025, 6, 21, 12, 48, 4, 0, time.UTC),
+			wantSunset:  time.Date(2025, 6, 22, 3, 34, 56, 0, time.UTC),
+		},
+		{
+			name: "london winter solstice",
+			lat:  51.5074, lon: -0.1278,
+			year: 2025, mon: 12, day: 21,
+			wantSunrise: time.Date(2025, 12, 21, 8, 3, 50, 0, time.UTC),
+			wantSunset:  time.Date(2025, 12, 21, 15, 53, 20, 0, time.UTC),
+		},
+		{
+			name: "tromso polar day",
+			lat:  69.6492, lon: 18.9553,
+			year: 2025, mon: 6, day: 21,
+			wantAlwaysUp: true,
+		},
+		{
+			name: "tromso polar night",
+			lat:  69.6492, lon: 18.9553,
+			year: 2025, mon: 12, day: 21,
+			wantAlwaysDown: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			date := time.Date(tc.year, time.Month(tc.mon), tc.day, 0, 0, 0, 0, time.UTC)
+			sunrise, sunset, alwaysUp, alwaysDown := sunriseSunset(tc.lat, tc.lon, date)
+
+			if alwaysUp != tc.wantAlwaysUp || alwaysDown != tc.wantAlwaysDown {
+				t.Fatalf("alwaysUp=%v alwaysDown=%v, want alwaysUp=%v alwaysDown=%v",
+					alwaysUp, alwaysDown, tc.wantAlwaysUp, tc.wantAlwaysDown)
+			}
+			if tc.wantAlwaysUp || tc.wantAlwaysDown {
+				return
+			}
+
+			if diff := sunrise.Sub(tc.wantSunrise); diff < -tolerance || diff > tolerance {
+				t.Errorf("sunrise = %v, want %v (±%v)", sunrise, tc.wantSunrise, tolerance)
+			}
+			if diff := sunset.Sub(tc.wantSunset); diff < -tolerance || diff > tolerance {
+				t.Errorf("sunset = %v, want %v (±%v)", sunset, tc.wantSunset, tolerance)
+			}
+		})
+	}
+}
+
+// TestConfigWindowPolarFallback covers the directional default (and explicit override) for
+// days the sun never rises or never sets.
+func TestConfigWindowPolarFallback(t *testing.T) {
+	summerSolstice := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	winterSolstice := time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)
+	const tromsoLat, tromsoLon = 69.6492, 18.9553
+
+	cases := []struct {
+		name          string
+		start         string
+		polarFallback string
+		date          time.Time
+		wantOpen      bool
+	}{
+		{name: "daylight window, polar day, default", start: "sunrise", date: summerSolstice, wantOpen: true},
+		{name: "daylight window, polar night, default", start: "sunrise", date: winterSolstice, wantOpen: false},
+		{name: "night window, polar day, default", start: "sunset", date: summerSolstice, wantOpen: false},
+		{name: "night window, polar night, default", start: "sunset", date: winterSolstice, wantOpen: true},
+		{name: "daylight window, polar night, forced always", start: "sunrise", polarFallback: "always", date: winterSolstice, wantOpen: true},
+		{name: "daylight window, polar day, forced never", start: "sunrise", polarFallback: "never", date: summerSolstice, wantOpen: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			end := "sunset"
+			if tc.start == "sunset" {
+				end = "sunrise"
+			}
+			cfg := &Config{Latitude: tromsoLat, Longitude: tromsoLon, Start: tc.start, End: end, PolarFallback: tc.polarFallback}
+
+			_, _, open, isPolar := cfg.Window(tc.date)
+			if !isPolar {
+				t.Fatalf("isPolar = false, want true")
+			}
+			if open != tc.wantOpen {
+				t.Errorf("open = %v, want %v", open, tc.wantOpen)
+			}
+		})
+	}
+}
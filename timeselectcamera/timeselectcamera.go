@@ -5,16 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/data"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/vision"
 	"go.viam.com/utils"
+
+	"viam-filtered-camera-selective-sync/internal/audit"
+	"viam-filtered-camera-selective-sync/internal/solarwindow"
 )
 
+// cronParser accepts standard 5-field expressions as well as 6-field expressions with a
+// leading seconds field, matching the "cron string" configs users may already have.
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // ScheduleHours defines start/end times for a weekday (HH:MM:SS)
 type ScheduleHours struct {
 	Start string `json:"start"`
@@ -27,6 +38,16 @@ type DateRange struct {
 	End   string `json:"end"`
 }
 
+// CronWindow opens a capture window each time cron fires, for the configured duration
+type CronWindow struct {
+	// Cron is a 5- or 6-field (seconds-optional) cron expression
+	Cron string `json:"cron"`
+	// Duration is a Go duration string (e.g. "15m") the window stays open after each fire
+	Duration string `json:"duration"`
+	// Timezone is an optional IANA name the cron expression is evaluated in; defaults to Timezone, then local
+	Timezone string `json:"timezone,omitempty"`
+}
+
 // Config holds configuration for time-select-capture camera
 // At least one of start_hours/end_hours, weekly_schedule, or schedule must be provided.
 type Config struct {
@@ -38,6 +59,22 @@ type Config struct {
 	WeeklySchedule map[string]ScheduleHours `json:"weekly_schedule,omitempty"`
 	// Explicit date ranges mode
 	Schedule []DateRange `json:"schedule,omitempty"`
+	// Cron windows mode
+	CronWindows []CronWindow `json:"cron_windows,omitempty"`
+	// Solar (sunrise/sunset) mode
+	Solar *solarwindow.Config `json:"solar,omitempty"`
+	// Trigger mode: gates capture on a predicate read from another sensor
+	Trigger *TriggerConfig `json:"trigger,omitempty"`
+	// AuditSize caps the number of decisions retained for DoCommand("get_audit"); defaults to 1000
+	AuditSize int `json:"audit_size,omitempty"`
+	// Timezone applies to start_hours/end_hours and weekly_schedule; defaults to local time
+	Timezone string `json:"timezone,omitempty"`
+	// Vision service gating: capture requires a matching detection in addition to the time window
+	VisionService  string   `json:"vision_service,omitempty"`
+	DetectorLabels []string `json:"detector_labels,omitempty"`
+	MinConfidence  float64  `json:"min_confidence,omitempty"`
+	// Combine determines how the time window and vision gates are combined: "and" (default) or "or"
+	Combine string `json:"combine,omitempty"`
 }
 
 // Validate ensures the configuration is correct and returns the camera dependency
@@ -49,9 +86,30 @@ func (cfg *Config) Validate(path string) ([]string, error) {
 	hours := cfg.StartHours != "" && cfg.EndHours != ""
 	weekly := len(cfg.WeeklySchedule) > 0
 	dates := len(cfg.Schedule) > 0
+	cronWindows := len(cfg.CronWindows) > 0
+	solar := cfg.Solar != nil
+	trigger := cfg.Trigger != nil
 
-	if !(hours || weekly || dates) {
-		return nil, fmt.Errorf("%s: must specify at least one of start_hours/end_hours, weekly_schedule, or schedule", path)
+	if !(hours || weekly || dates || cronWindows || solar || trigger) {
+		return nil, fmt.Errorf("%s: must specify at least one of start_hours/end_hours, weekly_schedule, schedule, cron_windows, solar, or trigger", path)
+	}
+
+	if solar {
+		if err := cfg.Solar.Validate(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if trigger {
+		if err := cfg.Trigger.Validate(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("%s: invalid timezone %q: %w", path, cfg.Timezone, err)
+		}
 	}
 
 	if hours {
@@ -98,7 +156,41 @@ func (cfg *Config) Validate(path string) ([]string, error) {
 		}
 	}
 
-	return []string{cfg.Camera}, nil
+	if cronWindows {
+		for i, cw := range cfg.CronWindows {
+			if _, err := cronParser.Parse(cw.Cron); err != nil {
+				return nil, fmt.Errorf("%s: cron_windows[%d].cron invalid expression %q: %w", path, i, cw.Cron, err)
+			}
+			if _, err := time.ParseDuration(cw.Duration); err != nil {
+				return nil, fmt.Errorf("%s: cron_windows[%d].duration invalid %q: %w", path, i, cw.Duration, err)
+			}
+			if cw.Timezone != "" {
+				if _, err := time.LoadLocation(cw.Timezone); err != nil {
+					return nil, fmt.Errorf("%s: cron_windows[%d].timezone invalid %q: %w", path, i, cw.Timezone, err)
+				}
+			}
+		}
+	}
+
+	deps := []string{cfg.Camera}
+
+	if cfg.VisionService != "" {
+		switch cfg.Combine {
+		case "", "and", "or":
+		default:
+			return nil, fmt.Errorf("%s: combine must be \"and\" or \"or\", got %q", path, cfg.Combine)
+		}
+		if len(cfg.DetectorLabels) == 0 {
+			return nil, fmt.Errorf("%s: detector_labels must be non-empty when vision_service is set", path)
+		}
+		deps = append(deps, cfg.VisionService)
+	}
+
+	if trigger {
+		deps = append(deps, cfg.Trigger.Sensor)
+	}
+
+	return deps, nil
 }
 
 var (
@@ -116,11 +208,81 @@ func init() {
 var Model = resource.NewModel("viam", "camera", "time-select-capture")
 
 type timedCamera struct {
-	name   resource.Name
-	logger logging.Logger
-	cfg    *Config
-	inner  camera.Camera
-	cancel func()
+	name        resource.Name
+	logger      logging.Logger
+	audit       *audit.Log
+	override    *audit.Override
+	resourceCtx context.Context
+	cancel      func()
+
+	// mu guards state, which Reconfigure replaces wholesale; readers (Image, decide, nextCronFire,
+	// Properties) snapshot it instead of reading fields off c directly, so a concurrent Reconfigure
+	// can never hand them a mix of old and new values. The trigger-poll goroutine does not read
+	// state: startTrigger hands it its TriggerConfig and triggerState directly.
+	mu    sync.RWMutex
+	state *camState
+}
+
+// camState holds the fields Reconfigure replaces as a unit
+type camState struct {
+	cfg         *Config
+	inner       camera.Camera
+	vis         vision.Service
+	loc         *time.Location
+	cronWindows []cronWindowRuntime
+	trigger     *triggerState
+}
+
+// snapshot returns the current state under a read lock. The returned *camState is never mutated
+// in place (Reconfigure always builds and installs a new one), so callers may read its fields
+// without further locking.
+func (c *timedCamera) snapshot() *camState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// cronWindowRuntime is a CronWindow with its cron expression, duration, and timezone parsed
+type cronWindowRuntime struct {
+	schedule cron.Schedule
+	duration time.Duration
+	loc      *time.Location
+}
+
+// buildCronWindows parses a Config's cron windows into their runtime form, falling back to
+// the Config's top-level timezone (and then local time) for any window without its own.
+func buildCronWindows(conf *Config) ([]cronWindowRuntime, error) {
+	defaultLoc := time.Local
+	if conf.Timezone != "" {
+		loc, err := time.LoadLocation(conf.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		defaultLoc = loc
+	}
+
+	windows := make([]cronWindowRuntime, 0, len(conf.CronWindows))
+	for _, cw := range conf.CronWindows {
+		schedule, err := cronParser.Parse(cw.Cron)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := time.ParseDuration(cw.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		loc := defaultLoc
+		if cw.Timezone != "" {
+			loc, err = time.LoadLocation(cw.Timezone)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		windows = append(windows, cronWindowRuntime{schedule: schedule, duration: duration, loc: loc})
+	}
+	return windows, nil
 }
 
 // newTimedCamera constructs and validates a timedCamera
@@ -144,14 +306,64 @@ func newTimedCamera(
 		return nil, fmt.Errorf("%s: failed to resolve camera: %w", rawConf.ResourceName(), err)
 	}
 
-	_, cancel := context.WithCancel(ctx)
-	return &timedCamera{
-		name:   rawConf.ResourceName(),
-		logger: logger,
-		cfg:    conf,
-		inner:  innerCam,
-		cancel: cancel,
-	}, nil
+	var visSvc vision.Service
+	if conf.VisionService != "" {
+		visSvc, err = vision.FromDependencies(deps, conf.VisionService)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to resolve vision service: %w", rawConf.ResourceName(), err)
+		}
+	}
+
+	loc := time.Local
+	if conf.Timezone != "" {
+		if loc, err = time.LoadLocation(conf.Timezone); err != nil {
+			return nil, fmt.Errorf("%s: invalid timezone: %w", rawConf.ResourceName(), err)
+		}
+	}
+
+	cronWindows, err := buildCronWindows(conf)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid cron_windows: %w", rawConf.ResourceName(), err)
+	}
+
+	resourceCtx, cancel := context.WithCancel(ctx)
+	c := &timedCamera{
+		name:        rawConf.ResourceName(),
+		logger:      logger,
+		audit:       audit.NewLog(conf.AuditSize),
+		override:    &audit.Override{},
+		resourceCtx: resourceCtx,
+		cancel:      cancel,
+	}
+
+	st := &camState{
+		cfg:         conf,
+		inner:       innerCam,
+		vis:         visSvc,
+		loc:         loc,
+		cronWindows: cronWindows,
+	}
+
+	if conf.Trigger != nil {
+		triggerSensor, err := sensor.FromDependencies(deps, conf.Trigger.Sensor)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("%s: failed to resolve trigger sensor: %w", rawConf.ResourceName(), err)
+		}
+		st.trigger = c.startTrigger(triggerSensor, conf.Trigger)
+	}
+
+	c.state = st
+	return c, nil
+}
+
+// startTrigger launches a goroutine polling triggerSensor per cfg and returns its triggerState
+// for the caller to install into the new camState
+func (c *timedCamera) startTrigger(triggerSensor sensor.Sensor, cfg *TriggerConfig) *triggerState {
+	pollCtx, pollCancel := context.WithCancel(c.resourceCtx)
+	ts := &triggerState{cancel: pollCancel}
+	go c.pollTrigger(pollCtx, triggerSensor, cfg, ts)
+	return ts
 }
 
 func (c *timedCamera) Reconfigure(
@@ -168,10 +380,74 @@ func (c *timedCamera) Reconfigure(
 		return err
 	}
 
-	c.cfg = conf
+	inner, err := camera.FromDependencies(deps, conf.Camera)
+	if err != nil {
+		return err
+	}
+
+	var vis vision.Service
+	if conf.VisionService != "" {
+		vis, err = vision.FromDependencies(deps, conf.VisionService)
+		if err != nil {
+			return err
+		}
+	}
+
+	loc := time.Local
+	if conf.Timezone != "" {
+		if loc, err = time.LoadLocation(conf.Timezone); err != nil {
+			return err
+		}
+	}
+
+	cronWindows, err := buildCronWindows(conf)
+	if err != nil {
+		return err
+	}
+
+	var triggerSensor sensor.Sensor
+	if conf.Trigger != nil {
+		triggerSensor, err = sensor.FromDependencies(deps, conf.Trigger.Sensor)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Dependencies are all resolved above before anything is mutated, so a failed Reconfigure
+	// leaves the existing state (and its trigger-poll goroutine, if any) running untouched.
+	if prev := c.snapshot(); prev.trigger != nil {
+		prev.trigger.cancel()
+	}
+
+	st := &camState{
+		cfg:         conf,
+		inner:       inner,
+		vis:         vis,
+		loc:         loc,
+		cronWindows: cronWindows,
+	}
+	if conf.Trigger != nil {
+		st.trigger = c.startTrigger(triggerSensor, conf.Trigger)
+	}
+
 	c.name = rawConf.ResourceName()
-	c.inner, err = camera.FromDependencies(deps, conf.Camera)
-	return err
+	c.mu.Lock()
+	c.state = st
+	c.mu.Unlock()
+
+	if c.audit.Size() != effectiveAuditSize(conf.AuditSize) {
+		c.audit.Resize(conf.AuditSize)
+	}
+
+	return nil
+}
+
+// effectiveAuditSize resolves the configured audit_size, applying the same default as audit.NewLog
+func effectiveAuditSize(configured int) int {
+	if configured <= 0 {
+		return audit.DefaultSize
+	}
+	return configured
 }
 
 // Image implements the gating logic around the inner camera
@@ -182,53 +458,213 @@ func (c *timedCamera) Image(
 ) ([]byte, camera.ImageMetadata, error) {
 	if extra != nil && extra["fromDataManagement"] == true {
 		now := time.Now()
-		if !c.inWindow(now) {
-			c.logger.Infof("%s: time %v outside window, skipping", c.name, now)
+
+		allow, reason, activeWindow, err := c.decide(ctx, now)
+		if err != nil {
+			return nil, camera.ImageMetadata{}, fmt.Errorf("%s: vision service detection failed: %w", c.name, err)
+		}
+
+		decision := "allowed"
+		if !allow {
+			decision = "skipped"
+		}
+		c.audit.Record(audit.Entry{
+			Timestamp:    now,
+			Decision:     decision,
+			Reason:       reason,
+			ActiveWindow: activeWindow,
+			MimeType:     mimeType,
+		})
+
+		if !allow {
+			c.logger.Infof("%s: %s, skipping", c.name, reason)
 			return nil, camera.ImageMetadata{}, ErrNoCapture
 		}
 	}
-	return c.inner.Image(ctx, mimeType, extra)
+	return c.snapshot().inner.Image(ctx, mimeType, extra)
+}
+
+// decide evaluates overrides, the configured time windows, and the vision service (if any)
+// to determine whether a capture should proceed, returning a human-readable reason and the
+// name of the window that allowed it (empty if none did, or if an override decided it).
+func (c *timedCamera) decide(ctx context.Context, now time.Time) (allow bool, reason string, activeWindow string, err error) {
+	if mode, active := c.override.Active(); active {
+		if mode == "open" {
+			return true, "force_open override active", "", nil
+		}
+		return false, "force_closed override active", "", nil
+	}
+
+	st := c.snapshot()
+	inWindow, activeWindow := c.windowMatch(st, now)
+
+	if st.vis == nil {
+		if !inWindow {
+			return false, "outside configured window", "", nil
+		}
+		return true, "inside configured window", activeWindow, nil
+	}
+
+	combine := st.cfg.Combine
+	if combine == "" {
+		combine = "and"
+	}
+
+	// Short-circuit "and" when the time window alone already fails, and "or" when it already
+	// succeeds, so we avoid an unnecessary detection call against the vision service — and, for
+	// "or", so a transient vision-service error doesn't turn an already-allowed capture into one.
+	if combine == "and" && !inWindow {
+		return false, "outside configured window", "", nil
+	}
+	if combine == "or" && inWindow {
+		return true, "inside configured window", activeWindow, nil
+	}
+
+	detected, err := c.detectionMatches(ctx, st)
+	if err != nil {
+		return false, "", "", err
+	}
+
+	if combine == "or" {
+		allow = inWindow || detected
+	} else {
+		allow = inWindow && detected
+	}
+
+	if !allow {
+		return false, fmt.Sprintf("in_window=%v detected=%v", inWindow, detected), "", nil
+	}
+	return true, fmt.Sprintf("in_window=%v detected=%v", inWindow, detected), activeWindow, nil
+}
+
+// detectionMatches queries the configured vision service for detections on the inner camera
+// and reports whether any detection satisfies the configured label and confidence filters.
+func (c *timedCamera) detectionMatches(ctx context.Context, st *camState) (bool, error) {
+	detections, err := st.vis.DetectionsFromCamera(ctx, st.cfg.Camera, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, det := range detections {
+		if det.Score() < st.cfg.MinConfidence {
+			continue
+		}
+		for _, label := range st.cfg.DetectorLabels {
+			if strings.EqualFold(det.Label(), label) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
 }
 
-// inWindow checks if t is within any configured window
-func (c *timedCamera) inWindow(t time.Time) bool {
+// windowMatch checks if t is within any configured window, and if so names which one matched
+func (c *timedCamera) windowMatch(st *camState, t time.Time) (bool, string) {
 	// Hours mode
-	if c.cfg.StartHours != "" && c.cfg.EndHours != "" {
-		sh, _ := time.Parse("15:04", c.cfg.StartHours)
-		eh, _ := time.Parse("15:04", c.cfg.EndHours)
-		start := time.Date(t.Year(), t.Month(), t.Day(), sh.Hour(), sh.Minute(), 0, 0, t.Location())
-		end := time.Date(t.Year(), t.Month(), t.Day(), eh.Hour(), eh.Minute(), 0, 0, t.Location())
+	if st.cfg.StartHours != "" && st.cfg.EndHours != "" {
+		local := t.In(st.loc)
+		sh, _ := time.Parse("15:04", st.cfg.StartHours)
+		eh, _ := time.Parse("15:04", st.cfg.EndHours)
+		start := time.Date(local.Year(), local.Month(), local.Day(), sh.Hour(), sh.Minute(), 0, 0, st.loc)
+		end := time.Date(local.Year(), local.Month(), local.Day(), eh.Hour(), eh.Minute(), 0, 0, st.loc)
 		if start.After(end) {
 			end = end.Add(24 * time.Hour)
 		}
-		return !t.Before(start) && !t.After(end)
+		if !local.Before(start) && !local.After(end) {
+			return true, "hours"
+		}
 	}
 
 	// Weekly schedule mode
-	if len(c.cfg.WeeklySchedule) > 0 {
-		day := strings.ToLower(t.Weekday().String()[:3])
-		if sh, ok := c.cfg.WeeklySchedule[day]; ok {
+	if len(st.cfg.WeeklySchedule) > 0 {
+		local := t.In(st.loc)
+		day := strings.ToLower(local.Weekday().String()[:3])
+		if sh, ok := st.cfg.WeeklySchedule[day]; ok {
 			ts, _ := time.Parse("15:04:05", sh.Start)
 			te, _ := time.Parse("15:04:05", sh.End)
-			start := time.Date(t.Year(), t.Month(), t.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, t.Location())
-			end := time.Date(t.Year(), t.Month(), t.Day(), te.Hour(), te.Minute(), te.Second(), 0, t.Location())
+			start := time.Date(local.Year(), local.Month(), local.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, st.loc)
+			end := time.Date(local.Year(), local.Month(), local.Day(), te.Hour(), te.Minute(), te.Second(), 0, st.loc)
 			if start.After(end) {
 				end = end.Add(24 * time.Hour)
 			}
-			return !t.Before(start) && !t.After(end)
+			if !local.Before(start) && !local.After(end) {
+				return true, "weekly_schedule"
+			}
 		}
 	}
 
 	// Explicit date ranges
-	for _, dr := range c.cfg.Schedule {
+	for _, dr := range st.cfg.Schedule {
 		start, _ := time.Parse(time.RFC3339, dr.Start)
 		end, _ := time.Parse(time.RFC3339, dr.End)
 		if !t.Before(start) && !t.After(end) {
-			return true
+			return true, "schedule"
+		}
+	}
+
+	// Cron windows
+	for _, cw := range st.cronWindows {
+		if last := lastCronFireBefore(cw.schedule, t.In(cw.loc)); !last.IsZero() {
+			if !t.Before(last) && t.Before(last.Add(cw.duration)) {
+				return true, "cron_windows"
+			}
 		}
 	}
 
-	return false
+	// Solar (sunrise/sunset) mode
+	if st.cfg.Solar != nil {
+		start, end, polarOpen, isPolar := st.cfg.Solar.Window(t)
+		if isPolar {
+			if polarOpen {
+				return true, "solar"
+			}
+		} else if !t.Before(start) && !t.After(end) {
+			return true, "solar"
+		}
+	}
+
+	// Trigger mode
+	if st.trigger != nil && st.trigger.inWindow(st.cfg.Trigger, t) {
+		return true, "trigger"
+	}
+
+	return false, ""
+}
+
+// lastCronFireBefore returns the most recent time sched would have fired at or before t,
+// or the zero time if none is found within a year of searching backward.
+func lastCronFireBefore(sched cron.Schedule, t time.Time) time.Time {
+	const maxLookback = 366 * 24 * time.Hour
+	for lookback := time.Hour; ; lookback *= 2 {
+		if lookback > maxLookback {
+			lookback = maxLookback
+		}
+		cursor := t.Add(-lookback)
+		var last time.Time
+		for next := sched.Next(cursor); !next.After(t); next = sched.Next(next) {
+			last = next
+		}
+		if !last.IsZero() {
+			return last
+		}
+		if lookback == maxLookback {
+			break
+		}
+	}
+	return time.Time{}
+}
+
+// nextCronFire returns the soonest upcoming fire time across all configured cron windows,
+// or the zero time if none are configured.
+func (c *timedCamera) nextCronFire(t time.Time) time.Time {
+	var next time.Time
+	for _, cw := range c.snapshot().cronWindows {
+		candidate := cw.schedule.Next(t.In(cw.loc))
+		if next.IsZero() || candidate.Before(next) {
+			next = candidate
+		}
+	}
+	return next
 }
 
 // Images is unimplemented
@@ -241,11 +677,65 @@ func (c *timedCamera) NextPointCloud(ctx context.Context) (pointcloud.PointCloud
 	return nil, ErrUnimplemented
 }
 
-// DoCommand is unimplemented
+// DoCommand exposes the audit log and manual window overrides for diagnostics
 func (c *timedCamera) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	_, ok := cmd["get_audit"]
+	if ok {
+		return map[string]interface{}{"entries": c.audit.Snapshot()}, nil
+	}
+
+	if _, ok := cmd["clear_audit"]; ok {
+		c.audit.Clear()
+		return map[string]interface{}{"cleared": true}, nil
+	}
+
+	if _, ok := cmd["stats"]; ok {
+		return c.audit.Stats(), nil
+	}
+
+	if _, ok := cmd["next_cron_fire"]; ok {
+		resp := map[string]interface{}{}
+		if next := c.nextCronFire(time.Now()); !next.IsZero() {
+			resp["next_cron_fire"] = next.Format(time.RFC3339)
+		}
+		return resp, nil
+	}
+
+	if raw, ok := cmd["force_open"]; ok {
+		d, err := overrideDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.override.Set("open", d)
+		return map[string]interface{}{"force_open": true, "duration": d.String()}, nil
+	}
+
+	if raw, ok := cmd["force_closed"]; ok {
+		d, err := overrideDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.override.Set("closed", d)
+		return map[string]interface{}{"force_closed": true, "duration": d.String()}, nil
+	}
+
+	if _, ok := cmd["clear_override"]; ok {
+		c.override.Clear()
+		return map[string]interface{}{"cleared": true}, nil
+	}
+
 	return nil, ErrUnimplemented
 }
 
+// overrideDuration parses the duration string passed alongside force_open/force_closed
+func overrideDuration(raw interface{}) (time.Duration, error) {
+	s, ok := raw.(string)
+	if !ok {
+		return 0, fmt.Errorf("duration must be a string, got %T", raw)
+	}
+	return time.ParseDuration(s)
+}
+
 // Close releases resources
 func (c *timedCamera) Close(ctx context.Context) error {
 	c.cancel()
@@ -259,7 +749,7 @@ func (c *timedCamera) Name() resource.Name {
 
 // Properties proxies to inner camera and disables PCD
 func (c *timedCamera) Properties(ctx context.Context) (camera.Properties, error) {
-	p, err := c.inner.Properties(ctx)
+	p, err := c.snapshot().inner.Properties(ctx)
 	if err == nil {
 		p.SupportsPCD = false
 	}
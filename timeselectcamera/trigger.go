@@ -0,0 +1,155 @@
+package timeselectcamera
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+)
+
+// TriggerConfig gates capture on a boolean/comparison predicate read from another sensor's
+// Readings, keeping the window open for PostTriggerDuration after the predicate flips false.
+type TriggerConfig struct {
+	Sensor string      `json:"sensor"`
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	// Operator compares the reading at Key against Value: "eq", "ne", "gt", "gte", "lt", "lte" (default "eq")
+	Operator string `json:"operator,omitempty"`
+	// PollInterval is a Go duration string for how often Sensor is polled (default "1s")
+	PollInterval string `json:"poll_interval,omitempty"`
+	// PostTriggerDuration is a Go duration string the window stays open after the predicate flips false
+	PostTriggerDuration string `json:"post_trigger_duration"`
+}
+
+// Validate checks the trigger config and its Go-duration fields
+func (t *TriggerConfig) Validate(path string) error {
+	if t.Sensor == "" {
+		return fmt.Errorf("%s: trigger.sensor is required", path)
+	}
+	if t.Key == "" {
+		return fmt.Errorf("%s: trigger.key is required", path)
+	}
+	switch t.Operator {
+	case "", "eq", "ne", "gt", "gte", "lt", "lte":
+	default:
+		return fmt.Errorf("%s: trigger.operator must be one of eq/ne/gt/gte/lt/lte, got %q", path, t.Operator)
+	}
+	if t.PollInterval != "" {
+		if _, err := time.ParseDuration(t.PollInterval); err != nil {
+			return fmt.Errorf("%s: trigger.poll_interval invalid %q: %w", path, t.PollInterval, err)
+		}
+	}
+	if t.PostTriggerDuration == "" {
+		return fmt.Errorf("%s: trigger.post_trigger_duration is required", path)
+	}
+	if _, err := time.ParseDuration(t.PostTriggerDuration); err != nil {
+		return fmt.Errorf("%s: trigger.post_trigger_duration invalid %q: %w", path, t.PostTriggerDuration, err)
+	}
+	return nil
+}
+
+// triggerState tracks the last time the configured predicate was observed true
+type triggerState struct {
+	mu       sync.Mutex
+	lastTrue time.Time
+	cancel   func()
+}
+
+// inWindow reports whether t falls within PostTriggerDuration of the last observed true reading
+func (ts *triggerState) inWindow(cfg *TriggerConfig, t time.Time) bool {
+	ts.mu.Lock()
+	lastTrue := ts.lastTrue
+	ts.mu.Unlock()
+
+	if lastTrue.IsZero() {
+		return false
+	}
+	postDuration, _ := time.ParseDuration(cfg.PostTriggerDuration)
+	return !t.Before(lastTrue) && t.Before(lastTrue.Add(postDuration))
+}
+
+// pollTrigger polls triggerSensor at cfg's interval, recording the time of each reading that
+// matches cfg's predicate.
+func (c *timedCamera) pollTrigger(ctx context.Context, triggerSensor sensor.Sensor, cfg *TriggerConfig, ts *triggerState) {
+	interval := time.Second
+	if cfg.PollInterval != "" {
+		if d, err := time.ParseDuration(cfg.PollInterval); err == nil {
+			interval = d
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			readings, err := triggerSensor.Readings(ctx, nil)
+			if err != nil {
+				c.logger.Warnf("%s: trigger sensor %q read failed: %v", c.name, cfg.Sensor, err)
+				continue
+			}
+			if triggerMatches(readings, cfg) {
+				ts.mu.Lock()
+				ts.lastTrue = time.Now()
+				ts.mu.Unlock()
+			}
+		}
+	}
+}
+
+// triggerMatches evaluates cfg's predicate against a Readings map
+func triggerMatches(readings map[string]interface{}, cfg *TriggerConfig) bool {
+	actual, ok := readings[cfg.Key]
+	if !ok {
+		return false
+	}
+
+	operator := cfg.Operator
+	if operator == "" {
+		operator = "eq"
+	}
+
+	switch operator {
+	case "eq":
+		return fmt.Sprintf("%v", actual) == fmt.Sprintf("%v", cfg.Value)
+	case "ne":
+		return fmt.Sprintf("%v", actual) != fmt.Sprintf("%v", cfg.Value)
+	case "gt", "gte", "lt", "lte":
+		a, aOk := toFloat(actual)
+		b, bOk := toFloat(cfg.Value)
+		if !aOk || !bOk {
+			return false
+		}
+		switch operator {
+		case "gt":
+			return a > b
+		case "gte":
+			return a >= b
+		case "lt":
+			return a < b
+		case "lte":
+			return a <= b
+		}
+	}
+	return false
+}
+
+// toFloat converts the numeric types a Readings map commonly holds to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
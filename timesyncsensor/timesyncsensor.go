@@ -6,11 +6,36 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
+
+	"viam-filtered-camera-selective-sync/internal/audit"
+	"viam-filtered-camera-selective-sync/internal/solarwindow"
 )
 
+// cronParser accepts standard 5-field expressions as well as 6-field expressions with a
+// leading seconds field, matching the "cron string" configs users may already have.
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// CronWindow opens a sync window each time cron fires, for the configured duration
+type CronWindow struct {
+	// Cron is a 5- or 6-field (seconds-optional) cron expression
+	Cron string `json:"cron"`
+	// Duration is a Go duration string (e.g. "15m") the window stays open after each fire
+	Duration string `json:"duration"`
+	// Timezone is an optional IANA name the cron expression is evaluated in; defaults to Timezone, then local
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// cronWindowRuntime is a CronWindow with its cron expression, duration, and timezone parsed
+type cronWindowRuntime struct {
+	schedule cron.Schedule
+	duration time.Duration
+	loc      *time.Location
+}
+
 // Init called upon import, registers this component with the module
 func init() {
 	resource.RegisterComponent(sensor.API, Model, resource.Registration[sensor.Sensor, *Config]{Constructor: newtimeSensor})
@@ -26,15 +51,27 @@ var Model = resource.NewModel("viam", "sensor", "time-select-sync")
 type Config struct {
 	StartHours string `json:"start_hours"`
 	EndHours   string `json:"end_hours"`
+	// CronWindows optionally adds cron-scheduled sync windows alongside start_hours/end_hours
+	CronWindows []CronWindow `json:"cron_windows,omitempty"`
+	// Solar optionally adds a sunrise/sunset sync window alongside start_hours/end_hours
+	Solar *solarwindow.Config `json:"solar,omitempty"`
+	// Timezone applies to start_hours/end_hours; defaults to local time
+	Timezone string `json:"timezone,omitempty"`
+	// AuditSize caps the number of decisions retained for DoCommand("get_audit"); defaults to 1000
+	AuditSize int `json:"audit_size,omitempty"`
 }
 
 // timeSensor represents the custom sensor struct
 type timeSensor struct {
-	name       resource.Name
-	logger     logging.Logger
-	cfg        *Config
-	cancelCtx  context.Context
-	cancelFunc func()
+	name        resource.Name
+	logger      logging.Logger
+	cfg         *Config
+	loc         *time.Location
+	cronWindows []cronWindowRuntime
+	audit       *audit.Log
+	override    *audit.Override
+	cancelCtx   context.Context
+	cancelFunc  func()
 }
 
 // Validate configuration and return implicit dependencies
@@ -51,9 +88,71 @@ func (cfg *Config) Validate(path string) ([]string, error) {
 		return nil, fmt.Errorf("invalid end_hours format (HH:MM) for component %q", path)
 	}
 
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q for component %q: %w", cfg.Timezone, path, err)
+		}
+	}
+
+	for i, cw := range cfg.CronWindows {
+		if _, err := cronParser.Parse(cw.Cron); err != nil {
+			return nil, fmt.Errorf("cron_windows[%d].cron invalid expression %q for component %q: %w", i, cw.Cron, path, err)
+		}
+		if _, err := time.ParseDuration(cw.Duration); err != nil {
+			return nil, fmt.Errorf("cron_windows[%d].duration invalid %q for component %q: %w", i, cw.Duration, path, err)
+		}
+		if cw.Timezone != "" {
+			if _, err := time.LoadLocation(cw.Timezone); err != nil {
+				return nil, fmt.Errorf("cron_windows[%d].timezone invalid %q for component %q: %w", i, cw.Timezone, path, err)
+			}
+		}
+	}
+
+	if cfg.Solar != nil {
+		if err := cfg.Solar.Validate(path); err != nil {
+			return nil, err
+		}
+	}
+
 	return []string{}, nil
 }
 
+// buildCronWindows parses a Config's cron windows into their runtime form, falling back to
+// the Config's top-level timezone (and then local time) for any window without its own.
+func buildCronWindows(conf *Config) ([]cronWindowRuntime, error) {
+	defaultLoc := time.Local
+	if conf.Timezone != "" {
+		loc, err := time.LoadLocation(conf.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		defaultLoc = loc
+	}
+
+	windows := make([]cronWindowRuntime, 0, len(conf.CronWindows))
+	for _, cw := range conf.CronWindows {
+		schedule, err := cronParser.Parse(cw.Cron)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := time.ParseDuration(cw.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		loc := defaultLoc
+		if cw.Timezone != "" {
+			loc, err = time.LoadLocation(cw.Timezone)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		windows = append(windows, cronWindowRuntime{schedule: schedule, duration: duration, loc: loc})
+	}
+	return windows, nil
+}
+
 // Constructor for timeSensor
 func newtimeSensor(ctx context.Context, deps resource.Dependencies, rawConf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
 	conf, err := resource.NativeConfig[*Config](rawConf)
@@ -61,14 +160,30 @@ func newtimeSensor(ctx context.Context, deps resource.Dependencies, rawConf reso
 		return nil, err
 	}
 
+	loc := time.Local
+	if conf.Timezone != "" {
+		if loc, err = time.LoadLocation(conf.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	cronWindows, err := buildCronWindows(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	cancelCtx, cancelFunc := context.WithCancel(ctx)
 
 	return &timeSensor{
-		name:       rawConf.ResourceName(),
-		logger:     logger,
-		cfg:        conf,
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
+		name:        rawConf.ResourceName(),
+		logger:      logger,
+		cfg:         conf,
+		loc:         loc,
+		cronWindows: cronWindows,
+		audit:       audit.NewLog(conf.AuditSize),
+		override:    &audit.Override{},
+		cancelCtx:   cancelCtx,
+		cancelFunc:  cancelFunc,
 	}, nil
 }
 
@@ -85,14 +200,41 @@ func (s *timeSensor) Reconfigure(ctx context.Context, deps resource.Dependencies
 		return err
 	}
 
+	loc := time.Local
+	if newConfig.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(newConfig.Timezone); err != nil {
+			return err
+		}
+	}
+
+	cronWindows, err := buildCronWindows(newConfig)
+	if err != nil {
+		return err
+	}
+
 	s.name = conf.ResourceName()
 	s.cfg = newConfig // Apply new configuration to struct
+	s.loc = loc
+	s.cronWindows = cronWindows
+	if s.audit.Size() != effectiveAuditSize(newConfig.AuditSize) {
+		s.audit.Resize(newConfig.AuditSize)
+	}
 	return nil
 }
 
+// effectiveAuditSize resolves the configured audit_size, applying the same default as audit.NewLog
+func effectiveAuditSize(configured int) int {
+	if configured <= 0 {
+		return audit.DefaultSize
+	}
+	return configured
+}
+
 // Readings returns a sync reading based on the configured hours, adjusting behavior if called by DataManager
 func (s *timeSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	currentTime := time.Now()
+	localTime := currentTime.In(s.loc)
 
 	startTime, err := time.Parse("15:04", s.cfg.StartHours)
 	if err != nil {
@@ -108,8 +250,8 @@ func (s *timeSensor) Readings(ctx context.Context, extra map[string]interface{})
 	var overnight bool = false
 
 	// Adjust start and end times to today's date for comparison
-	startTime = time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), startTime.Hour(), startTime.Minute(), 0, 0, currentTime.Location())
-	endTime = time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), endTime.Hour(), endTime.Minute(), 0, 0, currentTime.Location())
+	startTime = time.Date(localTime.Year(), localTime.Month(), localTime.Day(), startTime.Hour(), startTime.Minute(), 0, 0, s.loc)
+	endTime = time.Date(localTime.Year(), localTime.Month(), localTime.Day(), endTime.Hour(), endTime.Minute(), 0, 0, s.loc)
 
 	// Handle overnight period where start_time is later in the day than end_time
 	if startTime.After(endTime) {
@@ -117,26 +259,150 @@ func (s *timeSensor) Readings(ctx context.Context, extra map[string]interface{})
 		overnight = true
 	}
 
-	// Determine sync state
-	shouldSync := !currentTime.Before(startTime) && !currentTime.After(endTime)
+	// Determine sync state from the hours window, then fold in any cron windows
+	shouldSync := !localTime.Before(startTime) && !localTime.After(endTime)
+	activeWindow := ""
+	if shouldSync {
+		activeWindow = "hours"
+	}
+	for _, cw := range s.cronWindows {
+		if last := lastCronFireBefore(cw.schedule, currentTime.In(cw.loc)); !last.IsZero() {
+			if !currentTime.Before(last) && currentTime.Before(last.Add(cw.duration)) {
+				shouldSync = true
+				activeWindow = "cron_windows"
+			}
+		}
+	}
+	if s.cfg.Solar != nil {
+		solarStart, solarEnd, polarOpen, isPolar := s.cfg.Solar.Window(currentTime)
+		if isPolar {
+			if polarOpen {
+				shouldSync = true
+				activeWindow = "solar"
+			}
+		} else if !currentTime.Before(solarStart) && !currentTime.After(solarEnd) {
+			shouldSync = true
+			activeWindow = "solar"
+		}
+	}
 
-	// Return all relevant information without formatting for full details
-	return map[string]interface{}{
+	reason := fmt.Sprintf("should_sync=%v", shouldSync)
+	if mode, active := s.override.Active(); active {
+		shouldSync = mode == "open"
+		activeWindow = ""
+		reason = fmt.Sprintf("force_%s override active", mode)
+	}
+
+	decision := "skipped"
+	if shouldSync {
+		decision = "allowed"
+	}
+	s.audit.Record(audit.Entry{Timestamp: currentTime, Decision: decision, Reason: reason, ActiveWindow: activeWindow})
+
+	readings := map[string]interface{}{
 		"should_sync":                   shouldSync,
 		"overnight_time_range":          overnight,
-		"current_time":                  currentTime.Format("2006-01-02 15:04:05 MST"),
+		"current_time":                  localTime.Format("2006-01-02 15:04:05 MST"),
 		"start_time":                    startTime.Format("2006-01-02 15:04:05 MST"),
 		"end_time":                      endTime.Format("2006-01-02 15:04:05 MST"),
-		"currentTime.Before(startTime)": currentTime.Before(startTime),
-		"currentTime.After(endTime)":    currentTime.After(endTime),
-	}, nil
+		"currentTime.Before(startTime)": localTime.Before(startTime),
+		"currentTime.After(endTime)":    localTime.After(endTime),
+	}
+
+	if next := s.nextScheduledFire(currentTime); !next.IsZero() {
+		readings["next_scheduled_fire"] = next.Format(time.RFC3339)
+	}
+
+	return readings, nil
+}
+
+// nextScheduledFire returns the soonest upcoming cron fire across all configured cron windows,
+// or the zero time if none are configured.
+func (s *timeSensor) nextScheduledFire(t time.Time) time.Time {
+	var next time.Time
+	for _, cw := range s.cronWindows {
+		candidate := cw.schedule.Next(t.In(cw.loc))
+		if next.IsZero() || candidate.Before(next) {
+			next = candidate
+		}
+	}
+	return next
+}
+
+// lastCronFireBefore returns the most recent time sched would have fired at or before t,
+// or the zero time if none is found within a year of searching backward.
+func lastCronFireBefore(sched cron.Schedule, t time.Time) time.Time {
+	const maxLookback = 366 * 24 * time.Hour
+	for lookback := time.Hour; ; lookback *= 2 {
+		if lookback > maxLookback {
+			lookback = maxLookback
+		}
+		cursor := t.Add(-lookback)
+		var last time.Time
+		for next := sched.Next(cursor); !next.After(t); next = sched.Next(next) {
+			last = next
+		}
+		if !last.IsZero() {
+			return last
+		}
+		if lookback == maxLookback {
+			break
+		}
+	}
+	return time.Time{}
 }
 
-// DoCommand can be implemented to extend sensor functionality but returns unimplemented in this example.
+// DoCommand exposes the audit log and manual sync overrides for diagnostics, mirroring the
+// surface timeselectcamera exposes so both components share one diagnostic API.
 func (s *timeSensor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd["get_audit"]; ok {
+		return map[string]interface{}{"entries": s.audit.Snapshot()}, nil
+	}
+
+	if _, ok := cmd["clear_audit"]; ok {
+		s.audit.Clear()
+		return map[string]interface{}{"cleared": true}, nil
+	}
+
+	if _, ok := cmd["stats"]; ok {
+		return s.audit.Stats(), nil
+	}
+
+	if raw, ok := cmd["force_open"]; ok {
+		d, err := overrideDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		s.override.Set("open", d)
+		return map[string]interface{}{"force_open": true, "duration": d.String()}, nil
+	}
+
+	if raw, ok := cmd["force_closed"]; ok {
+		d, err := overrideDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		s.override.Set("closed", d)
+		return map[string]interface{}{"force_closed": true, "duration": d.String()}, nil
+	}
+
+	if _, ok := cmd["clear_override"]; ok {
+		s.override.Clear()
+		return map[string]interface{}{"cleared": true}, nil
+	}
+
 	return nil, errUnimplemented
 }
 
+// overrideDuration parses the duration string passed alongside force_open/force_closed
+func overrideDuration(raw interface{}) (time.Duration, error) {
+	s, ok := raw.(string)
+	if !ok {
+		return 0, fmt.Errorf("duration must be a string, got %T", raw)
+	}
+	return time.ParseDuration(s)
+}
+
 // Close cleans up the sensor
 func (s *timeSensor) Close(ctx context.Context) error {
 	s.cancelFunc()